@@ -0,0 +1,41 @@
+// Package book holds the Book domain model and the storage-agnostic
+// Repository interface used by the HTTP layer to read and write it.
+package book
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Book represents the details of a single book in the store.
+type Book struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Name      string             `bson:"name" json:"name"`
+	Author    string             `bson:"author" json:"author"`
+	Cost      float64            `bson:"cost" json:"cost"`
+	OwnerID   primitive.ObjectID `bson:"owner_id,omitempty" json:"owner_id,omitempty"`
+	CreatedAt time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// Repository is the storage contract the HTTP handlers depend on. Keeping
+// it separate from the Mongo implementation lets the handlers be tested
+// with a fake and lets the storage backend change without touching them.
+type Repository interface {
+	List(ctx context.Context, opts ListOptions) (ListResult, error)
+	Get(ctx context.Context, id primitive.ObjectID) (Book, error)
+	Create(ctx context.Context, book Book) (Book, error)
+	Update(ctx context.Context, id primitive.ObjectID, book Book) (Book, error)
+	Patch(ctx context.Context, id primitive.ObjectID, patch Patch) (Book, error)
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// Patch carries a partial update: only the fields set to non-nil are
+// changed, letting a caller patch a single field (e.g. just Cost) without
+// clobbering the rest of the book.
+type Patch struct {
+	Name   *string  `json:"name,omitempty"`
+	Author *string  `json:"author,omitempty"`
+	Cost   *float64 `json:"cost,omitempty"`
+}
@@ -0,0 +1,11 @@
+package book
+
+import "errors"
+
+// ErrNotFound is returned by Repository methods when no book matches the
+// given id.
+var ErrNotFound = errors.New("book: not found")
+
+// ErrDuplicate is returned by Repository methods when a book with the same
+// name and author already exists.
+var ErrDuplicate = errors.New("book: duplicate name/author")
@@ -0,0 +1,30 @@
+package book
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes creates the indexes the bookstore relies on: a unique
+// compound index on owner_id/name/author to reject duplicate entries on a
+// given user's shelf (without blocking two different users from owning
+// the same title), a text index on name/author for search, and an index
+// on cost for sort/range queries. It is safe to call on every startup.
+func EnsureIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "owner_id", Value: 1}, {Key: "name", Value: 1}, {Key: "author", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "name", Value: "text"}, {Key: "author", Value: "text"}},
+		},
+		{
+			Keys: bson.D{{Key: "cost", Value: 1}},
+		},
+	})
+	return err
+}
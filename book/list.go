@@ -0,0 +1,112 @@
+package book
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SortField is a field ListBooks can sort by.
+type SortField string
+
+// Supported sort fields.
+const (
+	SortName   SortField = "name"
+	SortAuthor SortField = "author"
+	SortCost   SortField = "cost"
+)
+
+// ValidSortField reports whether field is one ListBooks knows how to sort
+// by. The zero value is valid and means "use the default".
+func ValidSortField(field SortField) bool {
+	switch field {
+	case "", SortName, SortAuthor, SortCost:
+		return true
+	default:
+		return false
+	}
+}
+
+// SortDir is the direction of a ListBooks sort.
+type SortDir string
+
+// Supported sort directions.
+const (
+	Asc  SortDir = "asc"
+	Desc SortDir = "desc"
+)
+
+// DefaultLimit is the page size used when ListOptions.Limit is unset.
+const DefaultLimit = 20
+
+// ListOptions controls the List query: free-text search, a cost range,
+// sorting, and keyset pagination.
+type ListOptions struct {
+	Query     string
+	MinCost   *float64
+	MaxCost   *float64
+	Sort      SortField
+	Dir       SortDir
+	Limit     int64
+	PageToken string
+}
+
+// ListResult is a single page of books plus the token for the next page
+// and the total count matching the query.
+type ListResult struct {
+	Books         []Book
+	NextPageToken string
+	Total         int64
+}
+
+// pageCursor is the keyset pagination cursor encoded into a page token:
+// the sorted field's value on the last row of the previous page, plus
+// its id as a tiebreaker for rows that share that value.
+type pageCursor struct {
+	Value interface{} `json:"v"`
+	ID    string      `json:"id"`
+}
+
+// sortValue returns the value of b's sort field, for building and
+// comparing against a pageCursor.
+func sortValue(b Book, field SortField) interface{} {
+	switch field {
+	case SortAuthor:
+		return b.Author
+	case SortCost:
+		return b.Cost
+	default:
+		return b.Name
+	}
+}
+
+// EncodePageToken returns an opaque page token for the last row of a
+// page: sortValue is that row's value for the active sort field, and id
+// is its _id, used to break ties between rows with equal sortValue.
+func EncodePageToken(sortValue interface{}, id primitive.ObjectID) string {
+	raw, err := json.Marshal(pageCursor{Value: sortValue, ID: id.Hex()})
+	if err != nil {
+		return ""
+	}
+	return base64.URLEncoding.EncodeToString(raw)
+}
+
+// DecodePageToken reverses EncodePageToken, returning an error if token is
+// not a valid page token.
+func DecodePageToken(token string) (interface{}, primitive.ObjectID, error) {
+	raw, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, primitive.NilObjectID, fmt.Errorf("invalid page token: %w", err)
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, primitive.NilObjectID, fmt.Errorf("invalid page token: %w", err)
+	}
+	id, err := primitive.ObjectIDFromHex(cursor.ID)
+	if err != nil {
+		return nil, primitive.NilObjectID, fmt.Errorf("invalid page token: %w", err)
+	}
+	return cursor.Value, id, nil
+}
@@ -0,0 +1,70 @@
+package book
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestValidSortField(t *testing.T) {
+	cases := map[SortField]bool{
+		"":          true,
+		SortName:    true,
+		SortAuthor:  true,
+		SortCost:    true,
+		"publisher": false,
+	}
+	for field, want := range cases {
+		if got := ValidSortField(field); got != want {
+			t.Errorf("ValidSortField(%q) = %v, want %v", field, got, want)
+		}
+	}
+}
+
+func TestSortValue(t *testing.T) {
+	b := Book{Name: "Dune", Author: "Herbert", Cost: 9.99}
+
+	cases := []struct {
+		field SortField
+		want  interface{}
+	}{
+		{SortName, "Dune"},
+		{SortAuthor, "Herbert"},
+		{SortCost, 9.99},
+		{"", "Dune"}, // default sort field is name
+	}
+	for _, c := range cases {
+		if got := sortValue(b, c.field); got != c.want {
+			t.Errorf("sortValue(b, %q) = %v, want %v", c.field, got, c.want)
+		}
+	}
+}
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	cases := []interface{}{"Dune", 9.99}
+	for _, v := range cases {
+		token := EncodePageToken(v, id)
+		if token == "" {
+			t.Fatalf("EncodePageToken(%v, %v) returned empty token", v, id)
+		}
+
+		gotValue, gotID, err := DecodePageToken(token)
+		if err != nil {
+			t.Fatalf("DecodePageToken(%q) returned error: %v", token, err)
+		}
+		if gotID != id {
+			t.Errorf("DecodePageToken(%q) id = %v, want %v", token, gotID, id)
+		}
+		if gotValue != v {
+			t.Errorf("DecodePageToken(%q) value = %v (%T), want %v (%T)", token, gotValue, gotValue, v, v)
+		}
+	}
+}
+
+func TestDecodePageTokenInvalid(t *testing.T) {
+	if _, _, err := DecodePageToken("not-base64!"); err == nil {
+		t.Error("DecodePageToken with invalid base64 should return an error")
+	}
+}
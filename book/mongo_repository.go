@@ -0,0 +1,184 @@
+package book
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoRepository is the Mongo-backed implementation of Repository.
+type mongoRepository struct {
+	coll *mongo.Collection
+}
+
+// NewMongoRepository returns a Repository backed by the given collection.
+func NewMongoRepository(coll *mongo.Collection) Repository {
+	return &mongoRepository{coll: coll}
+}
+
+func (r *mongoRepository) List(ctx context.Context, opts ListOptions) (ListResult, error) {
+	filter := bson.M{}
+	if opts.Query != "" {
+		filter["$text"] = bson.M{"$search": opts.Query}
+	}
+
+	costRange := bson.M{}
+	if opts.MinCost != nil {
+		costRange["$gte"] = *opts.MinCost
+	}
+	if opts.MaxCost != nil {
+		costRange["$lte"] = *opts.MaxCost
+	}
+	if len(costRange) > 0 {
+		filter["cost"] = costRange
+	}
+
+	total, err := r.coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return ListResult{}, err
+	}
+
+	sortField := SortName
+	if opts.Sort != "" {
+		sortField = opts.Sort
+	}
+	dir := 1
+	cmp := "$gt"
+	if opts.Dir == Desc {
+		dir = -1
+		cmp = "$lt"
+	}
+
+	if opts.PageToken != "" {
+		afterValue, afterID, err := DecodePageToken(opts.PageToken)
+		if err != nil {
+			return ListResult{}, err
+		}
+		filter["$or"] = bson.A{
+			bson.M{string(sortField): bson.M{cmp: afterValue}},
+			bson.M{string(sortField): afterValue, "_id": bson.M{cmp: afterID}},
+		}
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: string(sortField), Value: dir}, {Key: "_id", Value: dir}}).
+		SetLimit(limit)
+
+	cursor, err := r.coll.Find(ctx, filter, findOpts)
+	if err != nil {
+		return ListResult{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var books []Book
+	for cursor.Next(ctx) {
+		var b Book
+		if err := cursor.Decode(&b); err != nil {
+			return ListResult{}, err
+		}
+		books = append(books, b)
+	}
+	if err := cursor.Err(); err != nil {
+		return ListResult{}, err
+	}
+
+	var nextPageToken string
+	if int64(len(books)) == limit {
+		last := books[len(books)-1]
+		nextPageToken = EncodePageToken(sortValue(last, sortField), last.ID)
+	}
+
+	return ListResult{Books: books, NextPageToken: nextPageToken, Total: total}, nil
+}
+
+func (r *mongoRepository) Get(ctx context.Context, id primitive.ObjectID) (Book, error) {
+	var b Book
+	err := r.coll.FindOne(ctx, bson.M{"_id": id}).Decode(&b)
+	if err == mongo.ErrNoDocuments {
+		return Book{}, ErrNotFound
+	}
+	if err != nil {
+		return Book{}, err
+	}
+	return b, nil
+}
+
+func (r *mongoRepository) Create(ctx context.Context, b Book) (Book, error) {
+	b.ID = primitive.NewObjectID()
+	b.CreatedAt = time.Now().UTC()
+	if _, err := r.coll.InsertOne(ctx, b); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return Book{}, ErrDuplicate
+		}
+		return Book{}, err
+	}
+	return b, nil
+}
+
+func (r *mongoRepository) Update(ctx context.Context, id primitive.ObjectID, b Book) (Book, error) {
+	update := bson.M{"$set": bson.M{
+		"name":   b.Name,
+		"author": b.Author,
+		"cost":   b.Cost,
+	}}
+	result, err := r.coll.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return Book{}, ErrDuplicate
+		}
+		return Book{}, err
+	}
+	if result.MatchedCount == 0 {
+		return Book{}, ErrNotFound
+	}
+	b.ID = id
+	return b, nil
+}
+
+func (r *mongoRepository) Patch(ctx context.Context, id primitive.ObjectID, p Patch) (Book, error) {
+	set := bson.M{}
+	if p.Name != nil {
+		set["name"] = *p.Name
+	}
+	if p.Author != nil {
+		set["author"] = *p.Author
+	}
+	if p.Cost != nil {
+		set["cost"] = *p.Cost
+	}
+	if len(set) == 0 {
+		return r.Get(ctx, id)
+	}
+
+	result, err := r.coll.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return Book{}, ErrDuplicate
+		}
+		return Book{}, err
+	}
+	if result.MatchedCount == 0 {
+		return Book{}, ErrNotFound
+	}
+	return r.Get(ctx, id)
+}
+
+func (r *mongoRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.coll.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
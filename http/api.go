@@ -0,0 +1,181 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"strings"
+
+	"github.com/Yagavi-A/go-mongo/book"
+	"github.com/Yagavi-A/go-mongo/user"
+)
+
+// apiError is the structured body returned for JSON API failures.
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w nethttp.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeJSONError(w nethttp.ResponseWriter, status int, message string) {
+	writeJSON(w, status, apiError{Error: message})
+}
+
+// APIBooks serves /api/v1/books: GET lists one page of books, filtered,
+// sorted and paginated by the query params parseListOptions reads; POST
+// creates one.
+func (h *Handler) APIBooks(w nethttp.ResponseWriter, r *nethttp.Request) {
+	switch r.Method {
+	case nethttp.MethodGet:
+		opts, err := parseListOptions(r)
+		if err != nil {
+			writeJSONError(w, nethttp.StatusBadRequest, "invalid query parameters")
+			return
+		}
+		result, err := h.repo.List(r.Context(), opts)
+		if err != nil {
+			writeJSONError(w, nethttp.StatusInternalServerError, "failed to get books")
+			return
+		}
+		writeJSON(w, nethttp.StatusOK, result)
+
+	case nethttp.MethodPost:
+		ownerID, err := user.Authenticate(r)
+		if err != nil {
+			writeJSONError(w, nethttp.StatusUnauthorized, "authentication required")
+			return
+		}
+
+		var b book.Book
+		if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+			writeJSONError(w, nethttp.StatusBadRequest, "invalid request body")
+			return
+		}
+		b.OwnerID = ownerID
+
+		created, err := h.repo.Create(r.Context(), b)
+		if err == book.ErrDuplicate {
+			writeJSONError(w, nethttp.StatusConflict, "a book with this name and author already exists")
+			return
+		}
+		if err != nil {
+			writeJSONError(w, nethttp.StatusInternalServerError, "failed to insert book")
+			return
+		}
+		writeJSON(w, nethttp.StatusCreated, created)
+
+	default:
+		writeJSONError(w, nethttp.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// APIBook serves /api/v1/books/{id}: GET by id, PUT for a full replace,
+// PATCH to set only the fields present in the body, and DELETE.
+func (h *Handler) APIBook(w nethttp.ResponseWriter, r *nethttp.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/books/")
+	objID, ok := h.parseID(w, r, id)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case nethttp.MethodGet:
+		b, err := h.repo.Get(r.Context(), objID)
+		if err == book.ErrNotFound {
+			writeJSONError(w, nethttp.StatusNotFound, "book not found")
+			return
+		}
+		if err != nil {
+			writeJSONError(w, nethttp.StatusInternalServerError, "failed to get book")
+			return
+		}
+		writeJSON(w, nethttp.StatusOK, b)
+
+	case nethttp.MethodPut:
+		ownerID, err := user.Authenticate(r)
+		if err != nil {
+			writeJSONError(w, nethttp.StatusUnauthorized, "authentication required")
+			return
+		}
+		if !h.checkOwner(w, r, objID, ownerID) {
+			return
+		}
+
+		var b book.Book
+		if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+			writeJSONError(w, nethttp.StatusBadRequest, "invalid request body")
+			return
+		}
+		updated, err := h.repo.Update(r.Context(), objID, b)
+		if err == book.ErrNotFound {
+			writeJSONError(w, nethttp.StatusNotFound, "book not found")
+			return
+		}
+		if err == book.ErrDuplicate {
+			writeJSONError(w, nethttp.StatusConflict, "a book with this name and author already exists")
+			return
+		}
+		if err != nil {
+			writeJSONError(w, nethttp.StatusInternalServerError, "failed to update book")
+			return
+		}
+		writeJSON(w, nethttp.StatusOK, updated)
+
+	case nethttp.MethodPatch:
+		ownerID, err := user.Authenticate(r)
+		if err != nil {
+			writeJSONError(w, nethttp.StatusUnauthorized, "authentication required")
+			return
+		}
+		if !h.checkOwner(w, r, objID, ownerID) {
+			return
+		}
+
+		var patch book.Patch
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			writeJSONError(w, nethttp.StatusBadRequest, "invalid request body")
+			return
+		}
+		updated, err := h.repo.Patch(r.Context(), objID, patch)
+		if err == book.ErrNotFound {
+			writeJSONError(w, nethttp.StatusNotFound, "book not found")
+			return
+		}
+		if err == book.ErrDuplicate {
+			writeJSONError(w, nethttp.StatusConflict, "a book with this name and author already exists")
+			return
+		}
+		if err != nil {
+			writeJSONError(w, nethttp.StatusInternalServerError, "failed to update book")
+			return
+		}
+		writeJSON(w, nethttp.StatusOK, updated)
+
+	case nethttp.MethodDelete:
+		ownerID, err := user.Authenticate(r)
+		if err != nil {
+			writeJSONError(w, nethttp.StatusUnauthorized, "authentication required")
+			return
+		}
+		if !h.checkOwner(w, r, objID, ownerID) {
+			return
+		}
+
+		err = h.repo.Delete(r.Context(), objID)
+		if err == book.ErrNotFound {
+			writeJSONError(w, nethttp.StatusNotFound, "book not found")
+			return
+		}
+		if err != nil {
+			writeJSONError(w, nethttp.StatusInternalServerError, "failed to delete book")
+			return
+		}
+		w.WriteHeader(nethttp.StatusNoContent)
+
+	default:
+		writeJSONError(w, nethttp.StatusMethodNotAllowed, "method not allowed")
+	}
+}
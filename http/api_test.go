@@ -0,0 +1,98 @@
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Yagavi-A/go-mongo/book"
+)
+
+func newTestHandler(books []book.Book) *Handler {
+	return NewHandler(&fakeRepository{books: books}, nil)
+}
+
+func decodeListResult(t *testing.T, rec *httptest.ResponseRecorder) book.ListResult {
+	t.Helper()
+	var result book.ListResult
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("decoding response body: %v", err)
+	}
+	return result
+}
+
+func TestAPIBooksSort(t *testing.T) {
+	h := newTestHandler([]book.Book{
+		{Name: "Dune", Author: "Herbert", Cost: 9.99},
+		{Name: "Annihilation", Author: "VanderMeer", Cost: 12.50},
+		{Name: "Circe", Author: "Miller", Cost: 7.25},
+	})
+
+	req := httptest.NewRequest(nethttp.MethodGet, "/api/v1/books?sort=name", nil)
+	rec := httptest.NewRecorder()
+	h.APIBooks(rec, req)
+
+	if rec.Code != nethttp.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, nethttp.StatusOK)
+	}
+	result := decodeListResult(t, rec)
+	want := []string{"Annihilation", "Circe", "Dune"}
+	if len(result.Books) != len(want) {
+		t.Fatalf("got %d books, want %d", len(result.Books), len(want))
+	}
+	for i, name := range want {
+		if result.Books[i].Name != name {
+			t.Errorf("books[%d].Name = %q, want %q", i, result.Books[i].Name, name)
+		}
+	}
+}
+
+func TestAPIBooksRejectsInvalidSort(t *testing.T) {
+	h := newTestHandler(nil)
+
+	req := httptest.NewRequest(nethttp.MethodGet, "/api/v1/books?sort=publisher", nil)
+	rec := httptest.NewRecorder()
+	h.APIBooks(rec, req)
+
+	if rec.Code != nethttp.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, nethttp.StatusBadRequest)
+	}
+}
+
+func TestAPIBooksPagination(t *testing.T) {
+	h := newTestHandler([]book.Book{
+		{ID: primitive.NewObjectID(), Name: "Alpha", Cost: 1},
+		{ID: primitive.NewObjectID(), Name: "Bravo", Cost: 2},
+		{ID: primitive.NewObjectID(), Name: "Charlie", Cost: 3},
+	})
+
+	req := httptest.NewRequest(nethttp.MethodGet, "/api/v1/books?sort=name&limit=2", nil)
+	rec := httptest.NewRecorder()
+	h.APIBooks(rec, req)
+
+	first := decodeListResult(t, rec)
+	if len(first.Books) != 2 {
+		t.Fatalf("first page: got %d books, want 2", len(first.Books))
+	}
+	if first.Books[0].Name != "Alpha" || first.Books[1].Name != "Bravo" {
+		t.Fatalf("first page order = %v", first.Books)
+	}
+	if first.NextPageToken == "" {
+		t.Fatal("expected a next page token")
+	}
+
+	req2 := httptest.NewRequest(nethttp.MethodGet, "/api/v1/books?sort=name&limit=2&after="+first.NextPageToken, nil)
+	rec2 := httptest.NewRecorder()
+	h.APIBooks(rec2, req2)
+
+	second := decodeListResult(t, rec2)
+	if len(second.Books) != 1 || second.Books[0].Name != "Charlie" {
+		t.Fatalf("second page = %v, want just Charlie", second.Books)
+	}
+	if second.NextPageToken != "" {
+		t.Errorf("expected no further page token, got %q", second.NextPageToken)
+	}
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Yagavi-A/go-mongo/book"
+)
+
+// fakeRepository is an in-memory book.Repository used to test the HTTP
+// layer without a Mongo instance. It supports just enough of List's
+// filtering, sorting and keyset pagination to exercise the handlers.
+type fakeRepository struct {
+	books []book.Book
+}
+
+func (f *fakeRepository) fieldValue(b book.Book, field book.SortField) interface{} {
+	switch field {
+	case book.SortAuthor:
+		return b.Author
+	case book.SortCost:
+		return b.Cost
+	default:
+		return b.Name
+	}
+}
+
+func (f *fakeRepository) List(ctx context.Context, opts book.ListOptions) (book.ListResult, error) {
+	matches := make([]book.Book, 0, len(f.books))
+	for _, b := range f.books {
+		if opts.Query != "" &&
+			!strings.Contains(strings.ToLower(b.Name), strings.ToLower(opts.Query)) &&
+			!strings.Contains(strings.ToLower(b.Author), strings.ToLower(opts.Query)) {
+			continue
+		}
+		if opts.MinCost != nil && b.Cost < *opts.MinCost {
+			continue
+		}
+		if opts.MaxCost != nil && b.Cost > *opts.MaxCost {
+			continue
+		}
+		matches = append(matches, b)
+	}
+	total := int64(len(matches))
+
+	sortField := opts.Sort
+	if sortField == "" {
+		sortField = book.SortName
+	}
+	desc := opts.Dir == book.Desc
+	sort.Slice(matches, func(i, j int) bool {
+		vi, vj := f.fieldValue(matches[i], sortField), f.fieldValue(matches[j], sortField)
+		var less bool
+		switch vi := vi.(type) {
+		case float64:
+			less = vi < vj.(float64)
+		case string:
+			less = vi < vj.(string)
+		}
+		if desc {
+			return !less && vi != vj
+		}
+		return less
+	})
+
+	if opts.PageToken != "" {
+		afterValue, afterID, err := book.DecodePageToken(opts.PageToken)
+		if err != nil {
+			return book.ListResult{}, err
+		}
+		afterIdx := -1
+		for i, b := range matches {
+			if b.ID == afterID {
+				afterIdx = i
+				break
+			}
+		}
+		_ = afterValue
+		if afterIdx >= 0 {
+			matches = matches[afterIdx+1:]
+		}
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = book.DefaultLimit
+	}
+
+	var nextPageToken string
+	if int64(len(matches)) > limit {
+		last := matches[limit-1]
+		nextPageToken = book.EncodePageToken(f.fieldValue(last, sortField), last.ID)
+		matches = matches[:limit]
+	}
+
+	return book.ListResult{Books: matches, NextPageToken: nextPageToken, Total: total}, nil
+}
+
+func (f *fakeRepository) Get(ctx context.Context, id primitive.ObjectID) (book.Book, error) {
+	for _, b := range f.books {
+		if b.ID == id {
+			return b, nil
+		}
+	}
+	return book.Book{}, book.ErrNotFound
+}
+
+func (f *fakeRepository) Create(ctx context.Context, b book.Book) (book.Book, error) {
+	b.ID = primitive.NewObjectID()
+	f.books = append(f.books, b)
+	return b, nil
+}
+
+func (f *fakeRepository) Update(ctx context.Context, id primitive.ObjectID, b book.Book) (book.Book, error) {
+	for i, existing := range f.books {
+		if existing.ID == id {
+			b.ID = id
+			f.books[i] = b
+			return b, nil
+		}
+	}
+	return book.Book{}, book.ErrNotFound
+}
+
+func (f *fakeRepository) Patch(ctx context.Context, id primitive.ObjectID, p book.Patch) (book.Book, error) {
+	for i, existing := range f.books {
+		if existing.ID == id {
+			if p.Name != nil {
+				existing.Name = *p.Name
+			}
+			if p.Author != nil {
+				existing.Author = *p.Author
+			}
+			if p.Cost != nil {
+				existing.Cost = *p.Cost
+			}
+			f.books[i] = existing
+			return existing, nil
+		}
+	}
+	return book.Book{}, book.ErrNotFound
+}
+
+func (f *fakeRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	for i, existing := range f.books {
+		if existing.ID == id {
+			f.books = append(f.books[:i], f.books[i+1:]...)
+			return nil
+		}
+	}
+	return book.ErrNotFound
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	nethttp "net/http"
+	"net/url"
+)
+
+// flashCookieName is the short-lived cookie a POST handler sets before
+// redirecting, and that Index reads once to render a banner.
+const flashCookieName = "flash"
+
+// setFlash stores message in a short-lived cookie for the next GET to
+// pick up and display.
+func setFlash(w nethttp.ResponseWriter, message string) {
+	nethttp.SetCookie(w, &nethttp.Cookie{
+		Name:   flashCookieName,
+		Value:  url.QueryEscape(message),
+		Path:   "/",
+		MaxAge: 30,
+	})
+}
+
+// readFlash returns the pending flash message, if any, and clears its
+// cookie so it's only shown once.
+func readFlash(w nethttp.ResponseWriter, r *nethttp.Request) string {
+	cookie, err := r.Cookie(flashCookieName)
+	if err != nil {
+		return ""
+	}
+	nethttp.SetCookie(w, &nethttp.Cookie{
+		Name:   flashCookieName,
+		Value:  "",
+		Path:   "/",
+		MaxAge: -1,
+	})
+	message, _ := url.QueryUnescape(cookie.Value)
+	return message
+}
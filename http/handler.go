@@ -0,0 +1,310 @@
+// Package http holds the HTTP transport layer for the bookstore: the
+// form-based handlers that render HTML and the JSON API handlers, both
+// built on top of a book.Repository received via constructor injection.
+package http
+
+import (
+	"html/template"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/Yagavi-A/go-mongo/book"
+	"github.com/Yagavi-A/go-mongo/csrf"
+	"github.com/Yagavi-A/go-mongo/user"
+)
+
+// Handler bundles the dependencies the bookstore HTTP routes need.
+type Handler struct {
+	repo book.Repository
+	tpl  *template.Template
+}
+
+// NewHandler returns a Handler serving books out of repo and rendering
+// pages with tpl.
+func NewHandler(repo book.Repository, tpl *template.Template) *Handler {
+	return &Handler{repo: repo, tpl: tpl}
+}
+
+// Routes returns a ServeMux with every bookstore route registered.
+func (h *Handler) Routes() *nethttp.ServeMux {
+	mux := nethttp.NewServeMux()
+	mux.HandleFunc("/", h.Index)
+	mux.Handle("/submit", user.RequireAuth(csrf.RequireCSRF(nethttp.HandlerFunc(h.Submit))))
+	mux.Handle("/delete", user.RequireAuth(csrf.RequireCSRF(nethttp.HandlerFunc(h.Delete))))
+	mux.Handle("/modify", user.RequireAuth(csrf.RequireCSRF(nethttp.HandlerFunc(h.Modify))))
+	mux.HandleFunc("/api/v1/books", h.APIBooks)
+	mux.HandleFunc("/api/v1/books/", h.APIBook)
+	return mux
+}
+
+// wantsJSON reports whether the client prefers a JSON response, either
+// because it hit an /api/v1 route or because it sent an Accept header
+// that asks for application/json and does not mention text/html at all.
+func wantsJSON(r *nethttp.Request) bool {
+	if strings.HasPrefix(r.URL.Path, "/api/v1/") {
+		return true
+	}
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "application/json") && !strings.Contains(accept, "text/html")
+}
+
+func (h *Handler) Index(w nethttp.ResponseWriter, r *nethttp.Request) {
+	if r.Method != nethttp.MethodGet {
+		nethttp.Error(w, "Method not allowed", nethttp.StatusMethodNotAllowed)
+		return
+	}
+
+	opts, err := parseListOptions(r)
+	if err != nil {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusBadRequest, "invalid query parameters")
+			return
+		}
+		nethttp.Error(w, "Invalid query parameters", nethttp.StatusBadRequest)
+		return
+	}
+
+	result, err := h.repo.List(r.Context(), opts)
+	if err != nil {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusInternalServerError, "failed to get books")
+			return
+		}
+		nethttp.Error(w, "Failed to get books", nethttp.StatusInternalServerError)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, nethttp.StatusOK, result)
+		return
+	}
+
+	data := struct {
+		Books         []book.Book
+		NextPageToken string
+		Total         int64
+		CSRFToken     string
+		Flash         string
+	}{
+		Books:         result.Books,
+		NextPageToken: result.NextPageToken,
+		Total:         result.Total,
+		CSRFToken:     csrf.Token(w, r),
+		Flash:         readFlash(w, r),
+	}
+	h.tpl.Execute(w, data)
+}
+
+func (h *Handler) Submit(w nethttp.ResponseWriter, r *nethttp.Request) {
+	if r.Method != nethttp.MethodPost {
+		nethttp.Error(w, "Method not allowed", nethttp.StatusMethodNotAllowed)
+		return
+	}
+
+	name := r.FormValue("name")
+	author := r.FormValue("author")
+	cost := r.FormValue("cost")
+
+	bookCost, err := strconv.ParseFloat(cost, 64)
+	if err != nil {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusBadRequest, "invalid cost")
+			return
+		}
+		nethttp.Error(w, "Invalid cost", nethttp.StatusBadRequest)
+		return
+	}
+
+	ownerID, _ := user.UserIDFromContext(r.Context())
+	created, err := h.repo.Create(r.Context(), book.Book{Name: name, Author: author, Cost: bookCost, OwnerID: ownerID})
+	if err == book.ErrDuplicate {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusConflict, "a book with this name and author already exists")
+			return
+		}
+		nethttp.Error(w, "A book with this name and author already exists", nethttp.StatusConflict)
+		return
+	}
+	if err != nil {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusInternalServerError, "failed to insert book")
+			return
+		}
+		nethttp.Error(w, "Failed to insert book", nethttp.StatusInternalServerError)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, nethttp.StatusCreated, created)
+		return
+	}
+
+	setFlash(w, "Book added successfully!")
+	nethttp.Redirect(w, r, "/", nethttp.StatusSeeOther)
+}
+
+func (h *Handler) Delete(w nethttp.ResponseWriter, r *nethttp.Request) {
+	if r.Method != nethttp.MethodPost {
+		nethttp.Error(w, "Method not allowed", nethttp.StatusMethodNotAllowed)
+		return
+	}
+
+	objID, ok := h.parseID(w, r, r.FormValue("id"))
+	if !ok {
+		return
+	}
+	ownerID, _ := user.UserIDFromContext(r.Context())
+	if !h.checkOwner(w, r, objID, ownerID) {
+		return
+	}
+
+	err := h.repo.Delete(r.Context(), objID)
+	if err == book.ErrNotFound {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusNotFound, "book not found")
+			return
+		}
+		setFlash(w, "Book not found!")
+		nethttp.Redirect(w, r, "/", nethttp.StatusSeeOther)
+		return
+	}
+	if err != nil {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusInternalServerError, "failed to delete book")
+			return
+		}
+		nethttp.Error(w, "Failed to delete book", nethttp.StatusInternalServerError)
+		return
+	}
+
+	if wantsJSON(r) {
+		w.WriteHeader(nethttp.StatusNoContent)
+		return
+	}
+
+	setFlash(w, "Book deleted successfully!")
+	nethttp.Redirect(w, r, "/", nethttp.StatusSeeOther)
+}
+
+func (h *Handler) Modify(w nethttp.ResponseWriter, r *nethttp.Request) {
+	if r.Method != nethttp.MethodPost {
+		nethttp.Error(w, "Method not allowed", nethttp.StatusMethodNotAllowed)
+		return
+	}
+
+	objID, ok := h.parseID(w, r, r.FormValue("id"))
+	if !ok {
+		return
+	}
+	ownerID, _ := user.UserIDFromContext(r.Context())
+	if !h.checkOwner(w, r, objID, ownerID) {
+		return
+	}
+
+	name := r.FormValue("name")
+	author := r.FormValue("author")
+	cost := r.FormValue("cost")
+
+	bookCost, err := strconv.ParseFloat(cost, 64)
+	if err != nil {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusBadRequest, "invalid cost")
+			return
+		}
+		nethttp.Error(w, "Invalid cost", nethttp.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.repo.Update(r.Context(), objID, book.Book{Name: name, Author: author, Cost: bookCost})
+	if err == book.ErrNotFound {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusNotFound, "book not found")
+			return
+		}
+		nethttp.Error(w, "Book not found", nethttp.StatusNotFound)
+		return
+	}
+	if err == book.ErrDuplicate {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusConflict, "a book with this name and author already exists")
+			return
+		}
+		nethttp.Error(w, "A book with this name and author already exists", nethttp.StatusConflict)
+		return
+	}
+	if err != nil {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusInternalServerError, "failed to update book")
+			return
+		}
+		nethttp.Error(w, "Failed to update book", nethttp.StatusInternalServerError)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, nethttp.StatusOK, updated)
+		return
+	}
+
+	setFlash(w, "Book modified successfully!")
+	nethttp.Redirect(w, r, "/", nethttp.StatusSeeOther)
+}
+
+// checkOwner loads the book with id and verifies it belongs to ownerID,
+// writing the appropriate error response and returning false if it
+// doesn't exist or isn't owned by the caller.
+func (h *Handler) checkOwner(w nethttp.ResponseWriter, r *nethttp.Request, id, ownerID primitive.ObjectID) bool {
+	existing, err := h.repo.Get(r.Context(), id)
+	if err == book.ErrNotFound {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusNotFound, "book not found")
+		} else {
+			nethttp.Error(w, "Book not found", nethttp.StatusNotFound)
+		}
+		return false
+	}
+	if err != nil {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusInternalServerError, "failed to get book")
+		} else {
+			nethttp.Error(w, "Failed to get book", nethttp.StatusInternalServerError)
+		}
+		return false
+	}
+	if existing.OwnerID != ownerID {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusForbidden, "not the owner of this book")
+		} else {
+			nethttp.Error(w, "Not the owner of this book", nethttp.StatusForbidden)
+		}
+		return false
+	}
+	return true
+}
+
+// parseID validates raw as a book id (taken from a form value or from the
+// URL path), writing the appropriate error response and returning ok=false
+// if it is missing or malformed.
+func (h *Handler) parseID(w nethttp.ResponseWriter, r *nethttp.Request, raw string) (primitive.ObjectID, bool) {
+	if raw == "" {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusBadRequest, "invalid book id")
+		} else {
+			nethttp.Error(w, "Invalid book ID", nethttp.StatusBadRequest)
+		}
+		return primitive.NilObjectID, false
+	}
+	objID, err := primitive.ObjectIDFromHex(raw)
+	if err != nil {
+		if wantsJSON(r) {
+			writeJSONError(w, nethttp.StatusBadRequest, "invalid book id")
+		} else {
+			nethttp.Error(w, "Invalid book ID", nethttp.StatusBadRequest)
+		}
+		return primitive.NilObjectID, false
+	}
+	return objID, true
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"fmt"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Yagavi-A/go-mongo/book"
+)
+
+// parseListOptions builds a book.ListOptions from the query params shared
+// by the index page and the JSON API: q, sort, min_cost, max_cost, limit,
+// and after (the page token).
+func parseListOptions(r *nethttp.Request) (book.ListOptions, error) {
+	q := r.URL.Query()
+	opts := book.ListOptions{
+		Query:     q.Get("q"),
+		PageToken: q.Get("after"),
+	}
+
+	sort, dir := parseSort(q.Get("sort"))
+	if !book.ValidSortField(sort) {
+		return book.ListOptions{}, fmt.Errorf("invalid sort field %q", sort)
+	}
+	opts.Sort, opts.Dir = sort, dir
+
+	if raw := q.Get("min_cost"); raw != "" {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return book.ListOptions{}, err
+		}
+		opts.MinCost = &v
+	}
+	if raw := q.Get("max_cost"); raw != "" {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return book.ListOptions{}, err
+		}
+		opts.MaxCost = &v
+	}
+	if raw := q.Get("limit"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return book.ListOptions{}, err
+		}
+		opts.Limit = v
+	}
+
+	return opts, nil
+}
+
+// parseSort splits a "field" or "field_desc"/"field_asc" sort param into
+// a book.SortField and book.SortDir, defaulting to ascending.
+func parseSort(raw string) (book.SortField, book.SortDir) {
+	if raw == "" {
+		return "", ""
+	}
+	dir := book.Asc
+	field := raw
+	if rest, ok := strings.CutSuffix(raw, "_desc"); ok {
+		field, dir = rest, book.Desc
+	} else if rest, ok := strings.CutSuffix(raw, "_asc"); ok {
+		field = rest
+	}
+	return book.SortField(field), dir
+}
@@ -0,0 +1,27 @@
+// Package migrations implements schema-versioned migrations for the
+// bookstore database, following the numbered-migration pattern: each
+// schema change is a small, ordered step recorded in a registry, and the
+// current version is tracked in a dedicated collection so migrations only
+// ever run once.
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Version identifies a migration using semantic versioning, e.g. "2.0.0".
+type Version string
+
+// Migration is a single ordered schema change.
+type Migration struct {
+	Version Version
+	Up      func(ctx context.Context, db *mongo.Database) error
+}
+
+// less reports whether v sorts strictly before other using semantic
+// version ordering.
+func (v Version) less(other Version) bool {
+	return compareVersions(string(v), string(other)) < 0
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/Yagavi-A/go-mongo/book"
+)
+
+// migration_2_0_0 backfills created_at on pre-existing books and creates
+// the indexes the books collection relies on.
+func migration_2_0_0(ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection("books")
+
+	_, err := coll.UpdateMany(ctx,
+		bson.M{"created_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"created_at": time.Now().UTC()}},
+	)
+	if err != nil {
+		return err
+	}
+
+	return book.EnsureIndexes(ctx, coll)
+}
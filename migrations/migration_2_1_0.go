@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/Yagavi-A/go-mongo/book"
+)
+
+// migration_2_1_0 replaces the unscoped name/author unique index created by
+// migration_2_0_0 with the owner-scoped one book.EnsureIndexes now creates.
+// Databases that already ran 2.0.0 recorded their version before that
+// scoping fix landed, so they're stuck with the old index forever unless a
+// later migration drops it explicitly; 2.0.0 itself must stay untouched
+// once shipped.
+func migration_2_1_0(ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection("books")
+
+	_, err := coll.Indexes().DropOne(ctx, "name_1_author_1")
+	if err != nil && !isIndexNotFound(err) {
+		return err
+	}
+
+	return book.EnsureIndexes(ctx, coll)
+}
+
+// isIndexNotFound reports whether err is Mongo's "index not found" error,
+// which DropOne returns on databases that never created the old index
+// (e.g. ones bootstrapped after the 2.0.0 scoping fix already landed).
+func isIndexNotFound(err error) bool {
+	cmdErr, ok := err.(mongo.CommandError)
+	return ok && cmdErr.Code == 27
+}
@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	schemaMigrationsCollection = "schema_migrations"
+	schemaVersionDocID         = "schema_version"
+)
+
+// Migrator runs the registered migrations against a database, tracking
+// progress in the schema_migrations collection.
+type Migrator struct {
+	db         *mongo.Database
+	migrations []Migration
+}
+
+// NewMigrator returns a Migrator for db with the built-in migration
+// registry.
+func NewMigrator(db *mongo.Database) *Migrator {
+	return &Migrator{db: db, migrations: registry}
+}
+
+type schemaVersionDoc struct {
+	ID      string  `bson:"_id"`
+	Version Version `bson:"version"`
+}
+
+// CurrentVersion returns the schema version recorded in
+// schema_migrations, or the empty Version if none has been recorded yet.
+func (m *Migrator) CurrentVersion(ctx context.Context) (Version, error) {
+	var doc schemaVersionDoc
+	err := m.db.Collection(schemaMigrationsCollection).FindOne(ctx, bson.M{"_id": schemaVersionDocID}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return doc.Version, nil
+}
+
+// Up runs every migration newer than the current recorded version, in
+// order, recording the new version after each one succeeds.
+func (m *Migrator) Up(ctx context.Context) error {
+	current, err := m.CurrentVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range m.migrations {
+		if !current.less(migration.Version) {
+			continue
+		}
+		if err := migration.Up(ctx, m.db); err != nil {
+			return err
+		}
+		if err := m.setVersion(ctx, migration.Version); err != nil {
+			return err
+		}
+		current = migration.Version
+	}
+	return nil
+}
+
+func (m *Migrator) setVersion(ctx context.Context, v Version) error {
+	_, err := m.db.Collection(schemaMigrationsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": schemaVersionDocID},
+		bson.M{"$set": bson.M{"version": v}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,8 @@
+package migrations
+
+// registry lists every migration in the order it must run. Append new
+// migrations here; never reorder or remove an entry that has shipped.
+var registry = []Migration{
+	{Version: "2.0.0", Up: migration_2_0_0},
+	{Version: "2.1.0", Up: migration_2_1_0},
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"strconv"
+	"strings"
+)
+
+// compareVersions compares two dotted numeric versions (e.g. "2.0.0"),
+// returning -1, 0, or 1 the same way strings.Compare does.
+func compareVersions(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var an, bn int
+		if i < len(as) {
+			an, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			bn, _ = strconv.Atoi(bs[i])
+		}
+		if an != bn {
+			if an < bn {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,87 @@
+package user
+
+import (
+	"net/http"
+	"time"
+)
+
+// Handler exposes the signup and login HTTP endpoints.
+type Handler struct {
+	repo Repository
+}
+
+// NewHandler returns a Handler backed by repo.
+func NewHandler(repo Repository) *Handler {
+	return &Handler{repo: repo}
+}
+
+// Signup registers a new account and starts a session for it.
+func (h *Handler) Signup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	email := r.FormValue("email")
+	password := r.FormValue("password")
+	if email == "" || password == "" {
+		http.Error(w, "Email and password are required", http.StatusBadRequest)
+		return
+	}
+
+	hash, err := HashPassword(password)
+	if err != nil {
+		http.Error(w, "Failed to create account", http.StatusInternalServerError)
+		return
+	}
+
+	u, err := h.repo.Create(r.Context(), email, hash)
+	if err == ErrEmailTaken {
+		http.Error(w, "Email already registered", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to create account", http.StatusInternalServerError)
+		return
+	}
+
+	h.startSession(w, r, u)
+}
+
+// Login starts a session for an existing account.
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	email := r.FormValue("email")
+	password := r.FormValue("password")
+
+	u, err := h.repo.GetByEmail(r.Context(), email)
+	if err != nil || !CheckPassword(u.PasswordHash, password) {
+		http.Error(w, "Invalid email or password", http.StatusUnauthorized)
+		return
+	}
+
+	h.startSession(w, r, u)
+}
+
+func (h *Handler) startSession(w http.ResponseWriter, r *http.Request, u User) {
+	token, err := issueSessionToken(u.ID)
+	if err != nil {
+		http.Error(w, "Failed to start session", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  time.Now().Add(sessionTTL),
+	})
+
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
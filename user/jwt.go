@@ -0,0 +1,61 @@
+package user
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Session cookie configuration.
+const (
+	sessionCookieName = "session"
+	sessionTTL        = 24 * time.Hour
+)
+
+// jwtSecret signs session tokens. JWT_SECRET should be set in any real
+// deployment; the fallback is only good enough for local development.
+var jwtSecret = []byte(signingKey())
+
+func signingKey() string {
+	if key := os.Getenv("JWT_SECRET"); key != "" {
+		return key
+	}
+	return "dev-secret-change-me"
+}
+
+type sessionClaims struct {
+	jwt.RegisteredClaims
+}
+
+// issueSessionToken returns a signed JWT identifying userID, valid for
+// sessionTTL.
+func issueSessionToken(userID primitive.ObjectID) (string, error) {
+	claims := sessionClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID.Hex(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(sessionTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(jwtSecret)
+}
+
+// parseSessionToken validates tokenString and returns the user id it
+// identifies.
+func parseSessionToken(tokenString string) (primitive.ObjectID, error) {
+	var claims sessionClaims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return jwtSecret, nil
+	})
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return primitive.ObjectIDFromHex(claims.Subject)
+}
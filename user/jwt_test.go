@@ -0,0 +1,48 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestParseSessionTokenRoundTrip(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	token, err := issueSessionToken(userID)
+	if err != nil {
+		t.Fatalf("issueSessionToken: %v", err)
+	}
+
+	got, err := parseSessionToken(token)
+	if err != nil {
+		t.Fatalf("parseSessionToken: %v", err)
+	}
+	if got != userID {
+		t.Errorf("parseSessionToken returned %v, want %v", got, userID)
+	}
+}
+
+func TestParseSessionTokenRejectsNonHMAC(t *testing.T) {
+	claims := sessionClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   primitive.NewObjectID().Hex(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(sessionTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	// alg "none" carries no signature at all, and is the classic
+	// algorithm-confusion attack against naive JWT verifiers.
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	token, err := unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("signing unsigned token: %v", err)
+	}
+
+	if _, err := parseSessionToken(token); err == nil {
+		t.Error("parseSessionToken accepted a token signed with alg \"none\"")
+	}
+}
@@ -0,0 +1,44 @@
+package user
+
+import (
+	"context"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type contextKey int
+
+const userIDContextKey contextKey = 0
+
+// Authenticate reads and validates the session cookie on r, returning the
+// authenticated user's id.
+func Authenticate(r *http.Request) (primitive.ObjectID, error) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return parseSessionToken(cookie.Value)
+}
+
+// UserIDFromContext returns the user id RequireAuth stored on the request
+// context, if any.
+func UserIDFromContext(ctx context.Context) (primitive.ObjectID, bool) {
+	id, ok := ctx.Value(userIDContextKey).(primitive.ObjectID)
+	return id, ok
+}
+
+// RequireAuth rejects requests without a valid session cookie with 401,
+// and otherwise makes the authenticated user id available to next via
+// UserIDFromContext.
+func RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, err := Authenticate(r)
+		if err != nil {
+			http.Error(w, "Authentication required", http.StatusUnauthorized)
+			return
+		}
+		ctx := context.WithValue(r.Context(), userIDContextKey, userID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
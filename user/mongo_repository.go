@@ -0,0 +1,59 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoRepository is the Mongo-backed implementation of Repository.
+type mongoRepository struct {
+	coll *mongo.Collection
+}
+
+// NewMongoRepository returns a Repository backed by the given collection.
+func NewMongoRepository(coll *mongo.Collection) Repository {
+	return &mongoRepository{coll: coll}
+}
+
+// EnsureIndexes creates the unique index on email that Create relies on to
+// reject duplicate signups. It is safe to call on every startup.
+func EnsureIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+func (r *mongoRepository) Create(ctx context.Context, email, passwordHash string) (User, error) {
+	u := User{
+		ID:           primitive.NewObjectID(),
+		Email:        email,
+		PasswordHash: passwordHash,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if _, err := r.coll.InsertOne(ctx, u); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return User{}, ErrEmailTaken
+		}
+		return User{}, err
+	}
+	return u, nil
+}
+
+func (r *mongoRepository) GetByEmail(ctx context.Context, email string) (User, error) {
+	var u User
+	err := r.coll.FindOne(ctx, bson.M{"email": email}).Decode(&u)
+	if err == mongo.ErrNoDocuments {
+		return User{}, ErrNotFound
+	}
+	if err != nil {
+		return User{}, err
+	}
+	return u, nil
+}
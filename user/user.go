@@ -0,0 +1,32 @@
+// Package user handles account signup/login and the session tokens that
+// prove a request was made by a given user.
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// User is an account able to own books.
+type User struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Email        string             `bson:"email" json:"email"`
+	PasswordHash string             `bson:"password_hash" json:"-"`
+	CreatedAt    time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// Repository is the storage contract for users.
+type Repository interface {
+	Create(ctx context.Context, email, passwordHash string) (User, error)
+	GetByEmail(ctx context.Context, email string) (User, error)
+}
+
+// ErrEmailTaken is returned by Repository.Create when the email is already
+// registered.
+var ErrEmailTaken = errors.New("user: email already registered")
+
+// ErrNotFound is returned when no user matches the given email.
+var ErrNotFound = errors.New("user: not found")